@@ -0,0 +1,206 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+)
+
+// GeneratorConfig is the YAML file (--config) listing the services,
+// methods, and key types to generate code for.
+type GeneratorConfig struct {
+	// Packages overrides/extends the built-in pkgAliases table, mapping an
+	// API package import path to the alias the generated code uses for it
+	// (e.g. "ga", "alpha", "beta").
+	Packages []PackageConfig `json:"packages"`
+	// Services lists the service types to generate code for.
+	Services []ServiceConfig `json:"services"`
+}
+
+// PackageConfig is one entry of GeneratorConfig.Packages.
+type PackageConfig struct {
+	ImportPath string `json:"importPath"`
+	Alias      string `json:"alias"`
+}
+
+// ServiceConfig describes one generated service, e.g. "BackendServices".
+type ServiceConfig struct {
+	// Name is the unqualified service type name as declared in the API
+	// package, e.g. "BackendServices".
+	Name string `json:"name"`
+	// KeyType is the resource's key scope: "Zonal", "Regional", or
+	// "Global".
+	KeyType string `json:"keyType"`
+	// Versions lists the API package import paths (ga, alpha, beta, ...)
+	// this service is generated for.
+	Versions []string `json:"versions"`
+	// Methods lists the non-standard methods to generate for this
+	// service. A method not listed here is still generated using the
+	// MethodKind heuristic and default argument names.
+	Methods []MethodConfig `json:"methods"`
+}
+
+// MethodConfig is one entry of ServiceConfig.Methods.
+type MethodConfig struct {
+	// Name is the method name as declared on the service type, e.g. "Get".
+	Name string `json:"name"`
+	// Exclude, if true, skips generating this method entirely.
+	Exclude bool `json:"exclude"`
+	// Kind overrides the MethodKind heuristic in Method.init: one of
+	// "Get", "Operation", "Paged". Leave empty to use the heuristic.
+	Kind string `json:"kind"`
+	// ArgNames overrides the generated arg0/arg1/... parameter names, in
+	// order, for the method's non-key arguments.
+	ArgNames []string `json:"argNames"`
+}
+
+// LoadGeneratorConfig reads and parses the YAML config file at path.
+func LoadGeneratorConfig(path string) (*GeneratorConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %v", path, err)
+	}
+	var cfg GeneratorConfig
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %q: %v", path, err)
+	}
+	return &cfg, nil
+}
+
+// ApplyPackageAliases merges cfg.Packages into the generator's package ->
+// alias table (see pkgAliases), overriding any built-in default that names
+// the same import path.
+func (cfg *GeneratorConfig) ApplyPackageAliases() {
+	for _, p := range cfg.Packages {
+		pkgAliases[p.ImportPath] = p.Alias
+	}
+}
+
+// methodConfig returns the MethodConfig declared for name, if any.
+func (svc *ServiceConfig) methodConfig(name string) (MethodConfig, bool) {
+	for _, m := range svc.Methods {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return MethodConfig{}, false
+}
+
+// Validate loads cfg's declared packages and checks every service and
+// method against them, returning every problem found rather than stopping
+// at the first, so the generator can print a full diagnostic list instead
+// of panicking on the first unexpected method shape.
+func (cfg *GeneratorConfig) Validate() []error {
+	var errs []error
+	for _, svc := range cfg.Services {
+		switch svc.KeyType {
+		case "Zonal", "Regional", "Global":
+		default:
+			errs = append(errs, fmt.Errorf("service %q: invalid keyType %q", svc.Name, svc.KeyType))
+		}
+		if len(svc.Versions) == 0 {
+			errs = append(errs, fmt.Errorf("service %q: no versions declared", svc.Name))
+			continue
+		}
+		loaded, err := LoadMethods(svc.Versions, svc.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("service %q: %v", svc.Name, err))
+			continue
+		}
+		for _, v := range svc.Versions {
+			pkg, ok := loaded[v]
+			if !ok {
+				errs = append(errs, fmt.Errorf("service %q: package %q does not declare type %q", svc.Name, v, svc.Name))
+				continue
+			}
+			found := map[string]bool{}
+			for _, fn := range pkg.Methods {
+				found[fn.Name()] = true
+			}
+			for _, m := range svc.Methods {
+				if !found[m.Name] {
+					errs = append(errs, fmt.Errorf("service %q: method %q not found in %q", svc.Name, m.Name, v))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// BuildMethods loads cfg's declared packages and constructs the
+// non-standard Method for every (non-excluded) method declared on each
+// service, applying the service's key type and any per-method Kind/ArgNames
+// overrides. It returns every construction error rather than stopping at
+// the first; call Validate first to catch config mistakes up front.
+func (cfg *GeneratorConfig) BuildMethods() (map[string][]*Method, []error) {
+	methods := map[string][]*Method{}
+	var errs []error
+	for _, svc := range cfg.Services {
+		loaded, err := LoadMethods(svc.Versions, svc.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("service %q: %v", svc.Name, err))
+			continue
+		}
+		for _, v := range svc.Versions {
+			pkg, ok := loaded[v]
+			if !ok {
+				errs = append(errs, fmt.Errorf("service %q: package %q does not declare type %q", svc.Name, v, svc.Name))
+				continue
+			}
+			version, ok := pkgAliases[v]
+			if !ok {
+				errs = append(errs, fmt.Errorf("service %q: package %q has no alias; add it to GeneratorConfig.Packages", svc.Name, v))
+				continue
+			}
+			si := &ServiceInfo{Service: svc.Name, WrapType: svc.Name, keyType: parseKeyType(svc.KeyType), version: version}
+			for _, fn := range pkg.Methods {
+				mc, hasOverride := svc.methodConfig(fn.Name())
+				if hasOverride && mc.Exclude {
+					continue
+				}
+				var override *MethodConfig
+				if hasOverride {
+					override = &mc
+				}
+				m, err := newMethod(si, fn, pkg.Fset, override)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				methods[svc.Name] = append(methods[svc.Name], m)
+			}
+		}
+	}
+	return methods, errs
+}
+
+// parseKeyType converts a ServiceConfig.KeyType string to a KeyType,
+// defaulting to Zonal. Validate should be called first to reject anything
+// else.
+func parseKeyType(s string) KeyType {
+	switch s {
+	case "Regional":
+		return Regional
+	case "Global":
+		return Global
+	default:
+		return Zonal
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadConfig is the packages.Config used to load the API packages. Syntax
+// and type information (but not full dependency graphs) are required to
+// discover the shape of each service's methods.
+var loadConfig = &packages.Config{
+	Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+}
+
+// LoadedPackage holds the pieces of a loaded API package that newMethod
+// needs: the exported methods declared on its service type, and the
+// *token.FileSet they were parsed with (needed to render source positions
+// in generator errors).
+type LoadedPackage struct {
+	Fset    *token.FileSet
+	Methods []*types.Func
+}
+
+// LoadMethods loads importPaths and returns, for each loaded package, the
+// exported methods declared on the named struct type serviceTypeName,
+// keyed by the package's import path.
+func LoadMethods(importPaths []string, serviceTypeName string) (map[string]*LoadedPackage, error) {
+	pkgs, err := packages.Load(loadConfig, importPaths...)
+	if err != nil {
+		return nil, fmt.Errorf("loading %v: %v", importPaths, err)
+	}
+
+	ret := map[string]*LoadedPackage{}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("loading %q: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+		obj := pkg.Types.Scope().Lookup(serviceTypeName)
+		if obj == nil {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s is not a named type", pkg.PkgPath, serviceTypeName)
+		}
+		var fns []*types.Func
+		for i := 0; i < named.NumMethods(); i++ {
+			if fn := named.Method(i); fn.Exported() {
+				fns = append(fns, fn)
+			}
+		}
+		ret[pkg.PkgPath] = &LoadedPackage{Fset: pkg.Fset, Methods: fns}
+	}
+	return ret, nil
+}
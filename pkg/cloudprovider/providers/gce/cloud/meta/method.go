@@ -18,25 +18,44 @@ package meta
 
 import (
 	"fmt"
-	"reflect"
+	"go/token"
+	"go/types"
 	"strings"
 )
 
-func newArg(t reflect.Type) *arg {
+// pkgAliases maps a package import path (with any repo.../vendor/ prefix
+// stripped) to the alias the generated code uses for it, e.g.
+// "google.golang.org/api/compute/v1" -> "ga". GeneratorConfig.Packages can
+// extend or override these defaults.
+var pkgAliases = map[string]string{
+	"google.golang.org/api/compute/v1":       "ga",
+	"google.golang.org/api/compute/v0.alpha": "alpha",
+	"google.golang.org/api/compute/v0.beta":  "beta",
+}
+
+func newArg(t types.Type) *arg {
 	ret := &arg{}
 
 	// Dereference the pointer types to get at the underlying concrete type.
-Loop:
 	for {
-		switch t.Kind() {
-		case reflect.Ptr:
-			ret.numPtr++
-			t = t.Elem()
-		default:
-			ret.pkg = t.PkgPath()
-			ret.typeName += t.Name()
-			break Loop
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			break
 		}
+		ret.numPtr++
+		t = ptr.Elem()
+	}
+
+	switch u := t.(type) {
+	case *types.Named:
+		if pkg := u.Obj().Pkg(); pkg != nil {
+			ret.pkg = pkg.Path()
+		}
+		ret.typeName = u.Obj().Name()
+	case *types.Basic:
+		ret.typeName = u.Name()
+	default:
+		ret.typeName = t.String()
 	}
 	return ret
 }
@@ -46,52 +65,64 @@ type arg struct {
 	numPtr        int
 }
 
-func (a *arg) normalizedPkg() string {
+func (a *arg) normalizedPkg() (string, error) {
 	if a.pkg == "" {
-		return ""
+		return "", nil
 	}
 
 	// Strip the repo.../vendor/ prefix from the package path if present.
 	parts := strings.Split(a.pkg, "/")
-	// Remove vendor prefix.
 	for i := 0; i < len(parts); i++ {
 		if parts[i] == "vendor" {
 			parts = parts[i+1:]
 			break
 		}
 	}
-	switch strings.Join(parts, "/") {
-	case "google.golang.org/api/compute/v1":
-		return "ga."
-	case "google.golang.org/api/compute/v0.alpha":
-		return "alpha."
-	case "google.golang.org/api/compute/v0.beta":
-		return "beta."
-	default:
-		panic(fmt.Errorf("unhandled package %q", a.pkg))
+	alias, ok := pkgAliases[strings.Join(parts, "/")]
+	if !ok {
+		return "", fmt.Errorf("unhandled package %q; add it to GeneratorConfig.Packages", a.pkg)
 	}
+	return alias + ".", nil
 }
 
-func (a *arg) String() string {
+func (a *arg) String() (string, error) {
+	pkg, err := a.normalizedPkg()
+	if err != nil {
+		return "", err
+	}
 	var ret string
 	for i := 0; i < a.numPtr; i++ {
 		ret += "*"
 	}
-	ret += a.normalizedPkg()
+	ret += pkg
 	ret += a.typeName
-	return ret
+	return ret, nil
 }
 
-// newMethod returns a newly initialized method.
-func newMethod(s *ServiceInfo, m reflect.Method) *Method {
+// newMethod returns a newly initialized method for fn, a type-checked
+// method declaration. fset renders the source position of fn in any
+// returned error. override, if non-nil, can force the resolved MethodKind
+// and the generated arg0/arg1/... parameter names.
+func newMethod(s *ServiceInfo, fn *types.Func, fset *token.FileSet, override *MethodConfig) (*Method, error) {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s.%s is not a function", fset.Position(fn.Pos()), s.Service, fn.Name())
+	}
 	ret := &Method{
 		ServiceInfo: s,
-		m:           m,
+		fn:          fn,
+		sig:         sig,
+		pos:         fset.Position(fn.Pos()),
 		kind:        MethodOperation,
-		ReturnType:  "",
+		override:    override,
 	}
-	ret.init()
-	return ret
+	if override != nil {
+		ret.argNames = override.ArgNames
+	}
+	if err := ret.init(); err != nil {
+		return nil, err
+	}
+	return ret, nil
 }
 
 // MethodKind is the type of method that we are generated code for.
@@ -109,7 +140,10 @@ const (
 // Method is used to generate the calling code for non-standard methods.
 type Method struct {
 	*ServiceInfo
-	m reflect.Method
+	fn  *types.Func
+	sig *types.Signature
+	// pos is the source position of fn, used to annotate errors.
+	pos token.Position
 
 	kind MethodKind
 	// ReturnType is the return type for the method.
@@ -117,6 +151,23 @@ type Method struct {
 	// ItemType is the type of the individual elements returns from a
 	// Pages() call. This is only applicable for MethodPaged kind.
 	ItemType string
+
+	// override is the GeneratorConfig's MethodConfig for this method, if
+	// the config declared one.
+	override *MethodConfig
+	// argNames, if non-empty, overrides the generated arg0/arg1/...
+	// parameter names, in order, with GeneratorConfig-supplied names.
+	argNames []string
+}
+
+// paramName returns the generated name for the i'th (0-indexed) non-key
+// argument, using the GeneratorConfig override for this method if one was
+// supplied, or the default argN form otherwise.
+func (mr *Method) paramName(i int) string {
+	if i < len(mr.argNames) {
+		return mr.argNames[i]
+	}
+	return fmt.Sprintf("arg%d", i)
 }
 
 // IsOperation is true if the method is an Operation.
@@ -134,16 +185,18 @@ func (m *Method) IsGet() bool {
 	return m.kind == MethodGet
 }
 
-// argsSkip is the number of arguments to skip when generating the
-// synthesized method.
+// argsSkip is the number of leading parameters to skip when generating the
+// synthesized method: the key component arguments (projectID, zone/region,
+// resource name) that are replaced by a single *meta.Key. This is an
+// invariant of the (already validated) ServiceInfo.keyType, not something
+// discovered from the API shape, so an invalid value here is a generator
+// bug rather than a malformed input and is reported as a panic.
 func (mr *Method) argsSkip() int {
 	switch mr.keyType {
-	case Zonal:
-		return 4
-	case Regional:
-		return 4
-	case Global:
+	case Zonal, Regional:
 		return 3
+	case Global:
+		return 2
 	}
 	panic(fmt.Errorf("invalid KeyType %v", mr.keyType))
 }
@@ -151,101 +204,185 @@ func (mr *Method) argsSkip() int {
 // args return a list of arguments to the method, skipping the first skip
 // elements. If nameArgs is true, then the arguments will include a generated
 // parameter name (arg<N>). prefix will be added to the parameters.
-func (mr *Method) args(skip int, nameArgs bool, prefix []string) []string {
-	var args []*arg
-	fType := mr.m.Func.Type()
-	for i := 0; i < fType.NumIn(); i++ {
-		t := fType.In(i)
-		args = append(args, newArg(t))
-	}
+// Parameter names come from paramName, so MethodConfig.ArgNames replaces arg<N>.
+func (mr *Method) args(skip int, nameArgs bool, prefix []string) ([]string, error) {
+	params := mr.sig.Params()
 
 	var a []string
-	for i := skip; i < fType.NumIn(); i++ {
+	for i := skip; i < params.Len(); i++ {
+		s, err := newArg(params.At(i).Type()).String()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s.%s: %v", mr.pos, mr.Service, mr.Name(), err)
+		}
 		if nameArgs {
-			a = append(a, fmt.Sprintf("arg%d %s", i-skip, args[i]))
+			a = append(a, fmt.Sprintf("%s %s", mr.paramName(i-skip), s))
 		} else {
-			a = append(a, args[i].String())
+			a = append(a, s)
+		}
+	}
+	return append(prefix, a...), nil
+}
+
+// lookupMethod returns the method named name declared directly on named, if
+// any.
+func lookupMethod(named *types.Named, name string) (*types.Func, bool) {
+	for i := 0; i < named.NumMethods(); i++ {
+		if m := named.Method(i); m.Name() == name {
+			return m, true
 		}
 	}
-	return append(prefix, a...)
+	return nil, false
+}
+
+// isString reports whether t's underlying type is a string type.
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isError reports whether t is the predeclared error type.
+func isError(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() == nil && named.Obj().Name() == "error"
 }
 
 // init the method. This performs some rudimentary static checking as well as
 // determines the kind of method by looking at the shape (method signature) of
 // the object.
-func (mr *Method) init() {
-	fType := mr.m.Func.Type()
-	if fType.NumIn() < mr.argsSkip() {
-		err := fmt.Errorf("method %q.%q, arity = %d which is less than required (< %d)",
-			mr.Service, mr.Name(), fType.NumIn(), mr.argsSkip())
-		panic(err)
+func (mr *Method) init() error {
+	params := mr.sig.Params()
+	if params.Len() < mr.argsSkip() {
+		return fmt.Errorf("%s: %s.%s: arity = %d which is less than required (< %d)",
+			mr.pos, mr.Service, mr.Name(), params.Len(), mr.argsSkip())
 	}
 	// Skipped args should all be string (they will be projectID, zone, region etc).
-	for i := 1; i < mr.argsSkip(); i++ {
-		if fType.In(i).Kind() != reflect.String {
-			panic(fmt.Errorf("method %q.%q: skipped args can only be strings", mr.Service, mr.Name()))
+	for i := 0; i < mr.argsSkip(); i++ {
+		if !isString(params.At(i).Type()) {
+			return fmt.Errorf("%s: %s.%s: skipped args can only be strings", mr.pos, mr.Service, mr.Name())
 		}
 	}
 	// Return of the method must return a single value of type *xxxCall.
-	if fType.NumOut() != 1 || fType.Out(0).Kind() != reflect.Ptr || !strings.HasSuffix(fType.Out(0).Elem().Name(), "Call") {
-		panic(fmt.Errorf("method %q.%q: generator only supports methods returning an *xxxCall object",
-			mr.Service, mr.Name()))
+	results := mr.sig.Results()
+	if results.Len() != 1 {
+		return fmt.Errorf("%s: %s.%s: generator only supports methods returning a single *xxxCall object",
+			mr.pos, mr.Service, mr.Name())
+	}
+	returnPtr, ok := results.At(0).Type().(*types.Pointer)
+	if !ok {
+		return fmt.Errorf("%s: %s.%s: generator only supports methods returning an *xxxCall object",
+			mr.pos, mr.Service, mr.Name())
 	}
-	returnType := fType.Out(0)
-	returnTypeName := fType.Out(0).Elem().Name()
+	returnNamed, ok := returnPtr.Elem().(*types.Named)
+	if !ok || !strings.HasSuffix(returnNamed.Obj().Name(), "Call") {
+		return fmt.Errorf("%s: %s.%s: generator only supports methods returning an *xxxCall object",
+			mr.pos, mr.Service, mr.Name())
+	}
+	returnTypeName := returnNamed.Obj().Name()
 	// xxxCall must have a Do() method.
-	doMethod, ok := returnType.MethodByName("Do")
+	doMethod, ok := lookupMethod(returnNamed, "Do")
 	if !ok {
-		panic(fmt.Errorf("method %q.%q: return type %q does not have a Do() method",
-			mr.Service, mr.Name(), returnTypeName))
+		return fmt.Errorf("%s: %s.%s: return type %q does not have a Do() method",
+			mr.pos, mr.Service, mr.Name(), returnTypeName)
 	}
-	_, hasPages := returnType.MethodByName("Pages")
+	_, hasPages := lookupMethod(returnNamed, "Pages")
+	doSig := doMethod.Type().(*types.Signature)
 	// Do() method must return (*T, error).
-	switch doMethod.Func.Type().NumOut() {
+	var listNamed *types.Named
+	switch doSig.Results().Len() {
 	case 2:
-		out0 := doMethod.Func.Type().Out(0)
-		if out0.Kind() != reflect.Ptr {
-			panic(fmt.Errorf("method %q.%q: return type %q of Do() = S, _; S must be pointer type (%v)",
-				mr.Service, mr.Name(), returnTypeName, out0))
+		out0, ok := doSig.Results().At(0).Type().(*types.Pointer)
+		if !ok {
+			return fmt.Errorf("%s: %s.%s: return type %q of Do() = S, _; S must be pointer type (%v)",
+				mr.pos, mr.Service, mr.Name(), returnTypeName, doSig.Results().At(0).Type())
+		}
+		out0Named, ok := out0.Elem().(*types.Named)
+		if !ok {
+			return fmt.Errorf("%s: %s.%s: return type %q of Do() = S, _; S must be a named pointer type (%v)",
+				mr.pos, mr.Service, mr.Name(), returnTypeName, out0)
 		}
-		mr.ReturnType = out0.Elem().Name()
+		listNamed = out0Named
+		mr.ReturnType = out0Named.Obj().Name()
 		switch {
-		case out0.Elem().Name() == "Operation":
+		case mr.ReturnType == "Operation":
 			mr.kind = MethodOperation
 		case hasPages:
 			mr.kind = MethodPaged
-			// Pages() returns a xxxList that has the actual list
-			// of objects in the xxxList.Items field.
-			listType := out0.Elem()
-			itemsField, ok := listType.FieldByName("Items")
-			if !ok {
-				panic(fmt.Errorf("method %q.%q: paged return type %q does not have a .Items field", mr.Service, mr.Name(), listType.Name()))
-			}
-			// itemsField will be a []*ItemType. Dereference to
-			// extract the ItemType.
-			itemsType := itemsField.Type
-			if itemsType.Kind() != reflect.Slice && itemsType.Elem().Kind() != reflect.Ptr {
-				panic(fmt.Errorf("method %q.%q: paged return type %q.Items is not an array of pointers", mr.Service, mr.Name(), listType.Name()))
-			}
-			mr.ItemType = itemsType.Elem().Elem().Name()
 		default:
 			mr.kind = MethodGet
 		}
 		// Second argument must be "error".
-		if doMethod.Func.Type().Out(1).Name() != "error" {
-			panic(fmt.Errorf("method %q.%q: return type %q of Do() = S, T; T must be 'error'",
-				mr.Service, mr.Name(), returnTypeName))
+		if !isError(doSig.Results().At(1).Type()) {
+			return fmt.Errorf("%s: %s.%s: return type %q of Do() = S, T; T must be 'error'",
+				mr.pos, mr.Service, mr.Name(), returnTypeName)
 		}
-		break
 	default:
-		panic(fmt.Errorf("method %q.%q: %q Do() return type is not handled by the generator",
-			mr.Service, mr.Name(), returnTypeName))
+		return fmt.Errorf("%s: %s.%s: %q Do() return type is not handled by the generator",
+			mr.pos, mr.Service, mr.Name(), returnTypeName)
 	}
+	// The GeneratorConfig can force the MethodKind for cases where the
+	// shape heuristic above guesses wrong.
+	if mr.override != nil && mr.override.Kind != "" {
+		switch mr.override.Kind {
+		case "Get":
+			mr.kind = MethodGet
+		case "Operation":
+			mr.kind = MethodOperation
+		case "Paged":
+			mr.kind = MethodPaged
+		default:
+			return fmt.Errorf("%s: %s.%s: invalid MethodConfig.Kind %q", mr.pos, mr.Service, mr.Name(), mr.override.Kind)
+		}
+	}
+	// Pages() returns a xxxList that has the actual list of objects in the
+	// xxxList.Items field. Always (re-)derive ItemType from the final
+	// kind, since MethodConfig.Kind can force MethodPaged even when the
+	// hasPages heuristic above didn't take that branch.
+	if mr.kind == MethodPaged {
+		itemType, err := pagedItemType(mr.pos, mr.Service, mr.Name(), listNamed)
+		if err != nil {
+			return err
+		}
+		mr.ItemType = itemType
+	}
+	return nil
+}
+
+// pagedItemType extracts the ItemType for a MethodPaged method: Pages()
+// returns a xxxList whose .Items field is a []*ItemType.
+func pagedItemType(pos token.Position, service, name string, listNamed *types.Named) (string, error) {
+	listType, ok := listNamed.Underlying().(*types.Struct)
+	if !ok {
+		return "", fmt.Errorf("%s: %s.%s: paged return type %q is not a struct", pos, service, name, listNamed.Obj().Name())
+	}
+	var itemsType types.Type
+	for i := 0; i < listType.NumFields(); i++ {
+		if f := listType.Field(i); f.Name() == "Items" {
+			itemsType = f.Type()
+			break
+		}
+	}
+	if itemsType == nil {
+		return "", fmt.Errorf("%s: %s.%s: paged return type %q does not have a .Items field", pos, service, name, listNamed.Obj().Name())
+	}
+	// itemsType will be a []*ItemType. Dereference to extract the ItemType.
+	itemsSlice, ok := itemsType.(*types.Slice)
+	if !ok {
+		return "", fmt.Errorf("%s: %s.%s: paged return type %q.Items is not an array of pointers", pos, service, name, listNamed.Obj().Name())
+	}
+	itemsPtr, ok := itemsSlice.Elem().(*types.Pointer)
+	if !ok {
+		return "", fmt.Errorf("%s: %s.%s: paged return type %q.Items is not an array of pointers", pos, service, name, listNamed.Obj().Name())
+	}
+	itemsNamed, ok := itemsPtr.Elem().(*types.Named)
+	if !ok {
+		return "", fmt.Errorf("%s: %s.%s: paged return type %q.Items is not an array of pointers to a named type", pos, service, name, listNamed.Obj().Name())
+	}
+	return itemsNamed.Obj().Name(), nil
 }
 
 // Name is the name of the method.
 func (mr *Method) Name() string {
-	return mr.m.Name
+	return mr.fn.Name()
 }
 
 // CallArgs is a list of comma separated "argN" used for calling the method.
@@ -253,8 +390,9 @@ func (mr *Method) Name() string {
 // "arg0, arg1".
 func (mr *Method) CallArgs() string {
 	var args []string
-	for i := mr.argsSkip(); i < mr.m.Func.Type().NumIn(); i++ {
-		args = append(args, fmt.Sprintf("arg%d", i-mr.argsSkip()))
+	for i := mr.argsSkip(); i < mr.sig.Params().Len(); i++ {
+		// paramName substitutes any MethodConfig.ArgNames override for argN.
+		args = append(args, mr.paramName(i-mr.argsSkip()))
 	}
 	if len(args) == 0 {
 		return ""
@@ -264,73 +402,281 @@ func (mr *Method) CallArgs() string {
 
 // MockHookName is the name of the hook function in the mock.
 func (mr *Method) MockHookName() string {
-	return mr.m.Name + "Hook"
+	return mr.Name() + "Hook"
+}
+
+// gomockCallArgs is a list of the argument names ("ctx", "key", "arg0", ...)
+// used both to forward a call through to a gomock.Controller and to record
+// an expectation on the MockRecorder. It mirrors the parameter list produced
+// by FcnArgs.
+func (mr *Method) gomockCallArgs() []string {
+	args := []string{"ctx", "key"}
+	for i := mr.argsSkip(); i < mr.sig.Params().Len(); i++ {
+		args = append(args, mr.paramName(i-mr.argsSkip()))
+	}
+	if mr.kind == MethodPaged {
+		args = append(args, "fl")
+	}
+	return args
+}
+
+// GomockMethod is the definition of the method on the generated
+// MockXxxService, forwarding the call to the embedded gomock.Controller so
+// callers can set up expectations via EXPECT().
+func (mr *Method) GomockMethod() (string, error) {
+	args, err := mr.args(mr.argsSkip(), true, []string{
+		"ctx context.Context",
+		"key *meta.Key",
+	})
+	if err != nil {
+		return "", err
+	}
+	if mr.kind == MethodPaged {
+		args = append(args, "fl *filter.F")
+	}
+	callArgs := strings.Join(mr.gomockCallArgs(), ", ")
+
+	switch mr.kind {
+	case MethodOperation:
+		return fmt.Sprintf(`func (m *Mock%v) %v(%v) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, %q, %v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}`, mr.MockWrapType(), mr.Name(), strings.Join(args, ", "), mr.Name(), callArgs), nil
+	case MethodGet:
+		return fmt.Sprintf(`func (m *Mock%v) %v(%v) (*%v.%v, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, %q, %v)
+	ret0, _ := ret[0].(*%v.%v)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}`, mr.MockWrapType(), mr.Name(), strings.Join(args, ", "), mr.Version(), mr.ReturnType, mr.Name(), callArgs, mr.Version(), mr.ReturnType), nil
+	case MethodPaged:
+		return fmt.Sprintf(`func (m *Mock%v) %v(%v) ([]*%v.%v, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, %q, %v)
+	ret0, _ := ret[0].([]*%v.%v)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}`, mr.MockWrapType(), mr.Name(), strings.Join(args, ", "), mr.Version(), mr.ItemType, mr.Name(), callArgs, mr.Version(), mr.ItemType), nil
+	default:
+		return "", fmt.Errorf("%s: %s.%s: invalid method kind: %v", mr.pos, mr.Service, mr.Name(), mr.kind)
+	}
+}
+
+// GomockRecorderMethod is the definition of the method on the generated
+// MockXxxServiceMockRecorder, e.g. svc.EXPECT().Get(gomock.Any(), key).
+func (mr *Method) GomockRecorderMethod() string {
+	callArgs := mr.gomockCallArgs()
+	params := make([]string, len(callArgs))
+	for i, a := range callArgs {
+		params[i] = fmt.Sprintf("%s interface{}", a)
+	}
+	return fmt.Sprintf(`func (mr *Mock%vMockRecorder) %v(%v) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, %q, reflect.TypeOf((*Mock%v)(nil).%v), %v)
+}`, mr.MockWrapType(), mr.Name(), strings.Join(params, ", "), mr.Name(), mr.MockWrapType(), mr.Name(), strings.Join(callArgs, ", "))
 }
 
 // MockHook is the definition of the hook function.
-func (mr *Method) MockHook() string {
-	args := mr.args(mr.argsSkip(), false, []string{
+func (mr *Method) MockHook() (string, error) {
+	args, err := mr.args(mr.argsSkip(), false, []string{
 		fmt.Sprintf("*%s", mr.MockWrapType()),
 		"context.Context",
 		"*meta.Key",
 	})
+	if err != nil {
+		return "", err
+	}
 	if mr.kind == MethodPaged {
 		args = append(args, "*filter.F")
 	}
 
 	switch mr.kind {
 	case MethodOperation:
-		return fmt.Sprintf("%v func(%v) error", mr.MockHookName(), strings.Join(args, ", "))
+		return fmt.Sprintf("%v func(%v) error", mr.MockHookName(), strings.Join(args, ", ")), nil
 	case MethodGet:
-		return fmt.Sprintf("%v func(%v) (*%v.%v, error)", mr.MockHookName(), strings.Join(args, ", "), mr.Version(), mr.ReturnType)
+		return fmt.Sprintf("%v func(%v) (*%v.%v, error)", mr.MockHookName(), strings.Join(args, ", "), mr.Version(), mr.ReturnType), nil
 	case MethodPaged:
-		return fmt.Sprintf("%v func(%v) ([]*%v.%v, error)", mr.MockHookName(), strings.Join(args, ", "), mr.Version(), mr.ItemType)
+		return fmt.Sprintf("%v func(%v) ([]*%v.%v, error)", mr.MockHookName(), strings.Join(args, ", "), mr.Version(), mr.ItemType), nil
 	default:
-		panic(fmt.Errorf("invalid method kind: %v", mr.kind))
+		return "", fmt.Errorf("%s: %s.%s: invalid method kind: %v", mr.pos, mr.Service, mr.Name(), mr.kind)
 	}
 }
 
 // FcnArgs is the function signature for the definition of the method.
-func (mr *Method) FcnArgs() string {
-	args := mr.args(mr.argsSkip(), true, []string{
+func (mr *Method) FcnArgs() (string, error) {
+	args, err := mr.args(mr.argsSkip(), true, []string{
 		"ctx context.Context",
 		"key *meta.Key",
 	})
+	if err != nil {
+		return "", err
+	}
 	if mr.kind == MethodPaged {
 		args = append(args, "fl *filter.F")
 	}
 
 	switch mr.kind {
 	case MethodOperation:
-		return fmt.Sprintf("%v(%v) error", mr.m.Name, strings.Join(args, ", "))
+		return fmt.Sprintf("%v(%v) error", mr.Name(), strings.Join(args, ", ")), nil
 	case MethodGet:
-		return fmt.Sprintf("%v(%v) (*%v.%v, error)", mr.m.Name, strings.Join(args, ", "), mr.Version(), mr.ReturnType)
+		return fmt.Sprintf("%v(%v) (*%v.%v, error)", mr.Name(), strings.Join(args, ", "), mr.Version(), mr.ReturnType), nil
 	case MethodPaged:
-		return fmt.Sprintf("%v(%v) ([]*%v.%v, error)", mr.m.Name, strings.Join(args, ", "), mr.Version(), mr.ItemType)
+		return fmt.Sprintf("%v(%v) ([]*%v.%v, error)", mr.Name(), strings.Join(args, ", "), mr.Version(), mr.ItemType), nil
 	default:
-		panic(fmt.Errorf("invalid method kind: %v", mr.kind))
+		return "", fmt.Errorf("%s: %s.%s: invalid method kind: %v", mr.pos, mr.Service, mr.Name(), mr.kind)
 	}
 }
 
 // InterfaceFunc is the function declaration of the method in the interface.
-func (mr *Method) InterfaceFunc() string {
-	args := []string{
+func (mr *Method) InterfaceFunc() (string, error) {
+	args, err := mr.args(mr.argsSkip(), false, []string{
 		"context.Context",
 		"*meta.Key",
+	})
+	if err != nil {
+		return "", err
 	}
-	args = mr.args(mr.argsSkip(), false, args)
 	if mr.kind == MethodPaged {
 		args = append(args, "*filter.F")
 	}
 
 	switch mr.kind {
 	case MethodOperation:
-		return fmt.Sprintf("%v(%v) error", mr.m.Name, strings.Join(args, ", "))
+		return fmt.Sprintf("%v(%v) error", mr.Name(), strings.Join(args, ", ")), nil
 	case MethodGet:
-		return fmt.Sprintf("%v(%v) (*%v.%v, error)", mr.m.Name, strings.Join(args, ", "), mr.Version(), mr.ReturnType)
+		return fmt.Sprintf("%v(%v) (*%v.%v, error)", mr.Name(), strings.Join(args, ", "), mr.Version(), mr.ReturnType), nil
 	case MethodPaged:
-		return fmt.Sprintf("%v(%v) ([]*%v.%v, error)", mr.m.Name, strings.Join(args, ", "), mr.Version(), mr.ItemType)
+		return fmt.Sprintf("%v(%v) ([]*%v.%v, error)", mr.Name(), strings.Join(args, ", "), mr.Version(), mr.ItemType), nil
 	default:
-		panic(fmt.Errorf("invalid method kind: %v", mr.kind))
+		return "", fmt.Errorf("%s: %s.%s: invalid method kind: %v", mr.pos, mr.Service, mr.Name(), mr.kind)
+	}
+}
+
+// IsPagedStreaming is true if mr also generates a streaming, callback-based
+// variant (see StreamFcnArgs) alongside the slice-returning MethodPaged
+// form.
+func (mr *Method) IsPagedStreaming() bool {
+	return mr.kind == MethodPaged
+}
+
+// streamCallbackType is the type of the per-item callback passed to the
+// streaming variant of a MethodPaged method.
+func (mr *Method) streamCallbackType() string {
+	return fmt.Sprintf("func(*%v.%v) error", mr.Version(), mr.ItemType)
+}
+
+// StreamMethodName is the name of the generated streaming variant, e.g.
+// "ListStream" for "List".
+func (mr *Method) StreamMethodName() string {
+	return mr.Name() + "Stream"
+}
+
+// StreamMockHookName is the name of the streaming variant's hook function
+// field in the mock.
+func (mr *Method) StreamMockHookName() string {
+	return mr.StreamMethodName() + "Hook"
+}
+
+// StreamMockHook is the definition of the streaming variant's hook function
+// field.
+func (mr *Method) StreamMockHook() (string, error) {
+	if !mr.IsPagedStreaming() {
+		return "", fmt.Errorf("%s: %s.%s: StreamMockHook only applies to MethodPaged methods", mr.pos, mr.Service, mr.Name())
+	}
+	args, err := mr.args(mr.argsSkip(), false, []string{
+		fmt.Sprintf("*%s", mr.MockWrapType()),
+		"context.Context",
+		"*meta.Key",
+	})
+	if err != nil {
+		return "", err
+	}
+	args = append(args, "*filter.F", mr.streamCallbackType())
+	return fmt.Sprintf("%v func(%v) error", mr.StreamMockHookName(), strings.Join(args, ", ")), nil
+}
+
+// StreamFcnArgs is the function signature for the definition of the
+// streaming variant, which calls the callback per item instead of
+// buffering the whole paged result.
+func (mr *Method) StreamFcnArgs() (string, error) {
+	if !mr.IsPagedStreaming() {
+		return "", fmt.Errorf("%s: %s.%s: StreamFcnArgs only applies to MethodPaged methods", mr.pos, mr.Service, mr.Name())
+	}
+	args, err := mr.args(mr.argsSkip(), true, []string{
+		"ctx context.Context",
+		"key *meta.Key",
+	})
+	if err != nil {
+		return "", err
+	}
+	args = append(args, "fl *filter.F", fmt.Sprintf("f %s", mr.streamCallbackType()))
+	return fmt.Sprintf("%v(%v) error", mr.StreamMethodName(), strings.Join(args, ", ")), nil
+}
+
+// StreamInterfaceFunc is the function declaration of the streaming variant
+// in the interface.
+func (mr *Method) StreamInterfaceFunc() (string, error) {
+	if !mr.IsPagedStreaming() {
+		return "", fmt.Errorf("%s: %s.%s: StreamInterfaceFunc only applies to MethodPaged methods", mr.pos, mr.Service, mr.Name())
+	}
+	args, err := mr.args(mr.argsSkip(), false, []string{
+		"context.Context",
+		"*meta.Key",
+	})
+	if err != nil {
+		return "", err
+	}
+	args = append(args, "*filter.F", mr.streamCallbackType())
+	return fmt.Sprintf("%v(%v) error", mr.StreamMethodName(), strings.Join(args, ", ")), nil
+}
+
+// streamGomockCallArgs is gomockCallArgs plus the trailing callback
+// parameter used by the streaming variant.
+func (mr *Method) streamGomockCallArgs() []string {
+	return append(mr.gomockCallArgs(), "f")
+}
+
+// StreamGomockMethod is the definition of the streaming variant on the
+// generated MockXxxService, so gomock-based callers (ingress-gce,
+// cluster-autoscaler) get EXPECT()-style expectations for it too.
+func (mr *Method) StreamGomockMethod() (string, error) {
+	if !mr.IsPagedStreaming() {
+		return "", fmt.Errorf("%s: %s.%s: StreamGomockMethod only applies to MethodPaged methods", mr.pos, mr.Service, mr.Name())
+	}
+	args, err := mr.args(mr.argsSkip(), true, []string{
+		"ctx context.Context",
+		"key *meta.Key",
+	})
+	if err != nil {
+		return "", err
+	}
+	args = append(args, "fl *filter.F", fmt.Sprintf("f %s", mr.streamCallbackType()))
+	callArgs := strings.Join(mr.streamGomockCallArgs(), ", ")
+
+	return fmt.Sprintf(`func (m *Mock%v) %v(%v) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, %q, %v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}`, mr.MockWrapType(), mr.StreamMethodName(), strings.Join(args, ", "), mr.StreamMethodName(), callArgs), nil
+}
+
+// StreamGomockRecorderMethod is the definition of the streaming variant on
+// MockXxxServiceMockRecorder.
+func (mr *Method) StreamGomockRecorderMethod() (string, error) {
+	if !mr.IsPagedStreaming() {
+		return "", fmt.Errorf("%s: %s.%s: StreamGomockRecorderMethod only applies to MethodPaged methods", mr.pos, mr.Service, mr.Name())
+	}
+	callArgs := mr.streamGomockCallArgs()
+	params := make([]string, len(callArgs))
+	for i, a := range callArgs {
+		params[i] = fmt.Sprintf("%s interface{}", a)
 	}
+	return fmt.Sprintf(`func (mr *Mock%vMockRecorder) %v(%v) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, %q, reflect.TypeOf((*Mock%v)(nil).%v), %v)
+}`, mr.MockWrapType(), mr.StreamMethodName(), strings.Join(params, ", "), mr.StreamMethodName(), mr.MockWrapType(), mr.StreamMethodName(), strings.Join(callArgs, ", ")), nil
 }
@@ -0,0 +1,210 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fixtureGoMod = "module fixture\n\ngo 1.16\n"
+
+const fixtureSource = `package fixture
+
+type Instance struct{}
+
+type InstanceList struct {
+	Items []*Instance
+}
+
+type Operation struct{}
+
+type InstancesGetCall struct{}
+
+func (c *InstancesGetCall) Do() (*Instance, error) { return nil, nil }
+
+type InstancesListCall struct{}
+
+func (c *InstancesListCall) Do() (*InstanceList, error) { return nil, nil }
+func (c *InstancesListCall) Pages(f func(*InstanceList) error) error { return nil }
+
+type InstancesInsertCall struct{}
+
+func (c *InstancesInsertCall) Do() (*Operation, error) { return nil, nil }
+
+type InstancesAggregatedListCall struct{}
+
+func (c *InstancesAggregatedListCall) Do() (*InstanceList, error) { return nil, nil }
+
+type Instances struct{}
+
+func (s *Instances) Get(project, zone, instance string) *InstancesGetCall { return nil }
+func (s *Instances) List(project, zone, filter string) *InstancesListCall { return nil }
+func (s *Instances) Insert(project, zone, filter string) *InstancesInsertCall { return nil }
+func (s *Instances) AggregatedList(project, zone, filter string) *InstancesAggregatedListCall { return nil }
+`
+
+// loadFixtureMethods writes the fixture package above to a scratch module
+// and loads its Instances methods with LoadMethods.
+func loadFixtureMethods(t *testing.T) *LoadedPackage {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "meta-fixture")
+	if err != nil {
+		t.Fatalf("TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(fixtureGoMod), 0644); err != nil {
+		t.Fatalf("WriteFile(go.mod) = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "fixture.go"), []byte(fixtureSource), 0644); err != nil {
+		t.Fatalf("WriteFile(fixture.go) = %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() = %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir(%q) = %v", dir, err)
+	}
+	defer os.Chdir(cwd)
+
+	loaded, err := LoadMethods([]string{"."}, "Instances")
+	if err != nil {
+		t.Fatalf("LoadMethods() = %v", err)
+	}
+	pkg, ok := loaded["fixture"]
+	if !ok {
+		t.Fatalf("LoadMethods() = %v, want a %q entry", loaded, "fixture")
+	}
+	return pkg
+}
+
+func TestNewMethod(t *testing.T) {
+	pkg := loadFixtureMethods(t)
+	byName := map[string]*types.Func{}
+	for _, fn := range pkg.Methods {
+		byName[fn.Name()] = fn
+	}
+	svc := &ServiceInfo{Service: "Instances", WrapType: "Instances", keyType: Zonal, version: "ga"}
+
+	tests := []struct {
+		name     string
+		override *MethodConfig
+		wantKind MethodKind
+		wantItem string
+		wantErr  bool
+	}{
+		{name: "Get", wantKind: MethodGet},
+		{name: "List", wantKind: MethodPaged, wantItem: "Instance"},
+		{name: "Insert", wantKind: MethodOperation},
+		{
+			// AggregatedList has an .Items field but no Pages(), so the
+			// heuristic alone resolves it as MethodGet; the config can
+			// still force it to MethodPaged.
+			name:     "AggregatedList",
+			override: &MethodConfig{Kind: "Paged"},
+			wantKind: MethodPaged,
+			wantItem: "Instance",
+		},
+		{
+			// Forcing Paged on a method whose Do() return type has no
+			// .Items field must fail loudly, not silently emit an empty
+			// ItemType.
+			name:     "Get",
+			override: &MethodConfig{Kind: "Paged"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		fn, ok := byName[tc.name]
+		if !ok {
+			t.Fatalf("fixture method %q not found", tc.name)
+		}
+		m, err := newMethod(svc, fn, pkg.Fset, tc.override)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("newMethod(%q, override=%+v) = _, nil; want an error", tc.name, tc.override)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("newMethod(%q) = _, %v", tc.name, err)
+		}
+		if m.kind != tc.wantKind {
+			t.Errorf("newMethod(%q).kind = %v, want %v", tc.name, m.kind, tc.wantKind)
+		}
+		if m.ItemType != tc.wantItem {
+			t.Errorf("newMethod(%q).ItemType = %q, want %q", tc.name, m.ItemType, tc.wantItem)
+		}
+	}
+}
+
+// assertValidGoFunc fails t if src, wrapped in a bare package clause, does
+// not parse as syntactically valid Go. This catches Sprintf verb/arg
+// mismatches (e.g. a stray "%!v(MISSING)") without pinning the test to the
+// exact generated text.
+func assertValidGoFunc(t *testing.T, label, src string) {
+	t.Helper()
+	if _, err := parser.ParseFile(token.NewFileSet(), label, "package p\n\n"+src, 0); err != nil {
+		t.Errorf("%s does not parse as valid Go: %v\n%s", label, err, src)
+	}
+}
+
+func TestGomockMethod(t *testing.T) {
+	pkg := loadFixtureMethods(t)
+	byName := map[string]*types.Func{}
+	for _, fn := range pkg.Methods {
+		byName[fn.Name()] = fn
+	}
+	svc := &ServiceInfo{Service: "Instances", WrapType: "Instances", keyType: Zonal, version: "ga"}
+
+	for _, name := range []string{"Get", "List", "Insert"} {
+		m, err := newMethod(svc, byName[name], pkg.Fset, nil)
+		if err != nil {
+			t.Fatalf("newMethod(%q) = _, %v", name, err)
+		}
+
+		gm, err := m.GomockMethod()
+		if err != nil {
+			t.Fatalf("%s.GomockMethod() = _, %v", name, err)
+		}
+		assertValidGoFunc(t, name+".GomockMethod", gm)
+		assertValidGoFunc(t, name+".GomockRecorderMethod", m.GomockRecorderMethod())
+
+		if !m.IsPagedStreaming() {
+			continue
+		}
+		sm, err := m.StreamGomockMethod()
+		if err != nil {
+			t.Fatalf("%s.StreamGomockMethod() = _, %v", name, err)
+		}
+		assertValidGoFunc(t, name+".StreamGomockMethod", sm)
+		srm, err := m.StreamGomockRecorderMethod()
+		if err != nil {
+			t.Fatalf("%s.StreamGomockRecorderMethod() = _, %v", name, err)
+		}
+		assertValidGoFunc(t, name+".StreamGomockRecorderMethod", srm)
+	}
+}
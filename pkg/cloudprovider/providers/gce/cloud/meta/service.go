@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+// KeyType is the scope of the key used to identify a resource.
+type KeyType int
+
+const (
+	// Zonal is a zone-scoped resource.
+	Zonal KeyType = iota
+	// Regional is a region-scoped resource.
+	Regional
+	// Global is a project-scoped resource.
+	Global
+)
+
+// ServiceInfo carries the facts about a generated service that Method needs
+// to render its template helpers: the service's name, its key scope, the
+// API version it was loaded from, and the name used for its mock struct.
+type ServiceInfo struct {
+	// Service is the unqualified service type name, e.g. "BackendServices".
+	Service string
+	// WrapType is the name used for the generated wrapper/mock struct.
+	WrapType string
+
+	keyType KeyType
+	version string
+}
+
+// Version is the alias of the API package this service was loaded from,
+// e.g. "ga", "alpha", "beta".
+func (s *ServiceInfo) Version() string {
+	return s.version
+}
+
+// MockWrapType is the name used for the generated mock struct.
+func (s *ServiceInfo) MockWrapType() string {
+	return s.WrapType
+}